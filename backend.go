@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// DownstreamChannel holds the parsed values of a single row of the legacy
+// SC-QAM downstream channel table.
+type DownstreamChannel struct {
+	Channel, LockStatus, Modulation, ChannelID, Frequency    string
+	SNRDB, PowerDBMV, CorrectableErrors, UncorrectableErrors float64
+}
+
+// UpstreamChannel holds the parsed values of a single row of the legacy
+// SC-QAM upstream channel table.
+type UpstreamChannel struct {
+	Channel, LockStatus, ChannelType, ChannelID, Frequency string
+	PowerDBMV, SymbolRate                                  float64
+}
+
+// DownstreamOFDMChannel holds the parsed values of a single row of the
+// DOCSIS 3.1 OFDM downstream channel table.
+type DownstreamOFDMChannel struct {
+	Channel, LockStatus, Modulation, ChannelID, FFTType, Frequency   string
+	PowerDBMV, PLCPowerDBMV, MERDB                                   float64
+	UnerroredCodewords, CorrectableCodewords, UncorrectableCodewords float64
+}
+
+// UpstreamOFDMAChannel holds the parsed values of a single row of the
+// DOCSIS 3.1 OFDMA upstream channel table.
+type UpstreamOFDMAChannel struct {
+	Channel, LockStatus, ChannelType, ChannelID, Frequency string
+	PowerDBMV                                              float64
+}
+
+// ModemStatus is the vendor/model-agnostic result of scraping a modem.
+type ModemStatus struct {
+	DownstreamChannels     []DownstreamChannel
+	UpstreamChannels       []UpstreamChannel
+	DownstreamOFDMChannels []DownstreamOFDMChannel
+	UpstreamOFDMAChannels  []UpstreamOFDMAChannel
+
+	// EventLogEntries counts the entries present in the modem's event log,
+	// keyed by severity. Nil for models with no event log page.
+	EventLogEntries map[string]float64
+
+	// StatusCode is the HTTP status code of the last page fetched during
+	// the scrape.
+	StatusCode int
+}
+
+// ScrapeErrorReason categorizes why a ModemBackend.Scrape call failed, so
+// callers can use it as a metric label value without string-matching the
+// error text.
+type ScrapeErrorReason string
+
+const (
+	// ReasonHTTP means the modem returned a non-2xx HTTP status.
+	ReasonHTTP ScrapeErrorReason = "http"
+	// ReasonParse means the response was fetched but no channels could be
+	// parsed out of it, e.g. because the page layout changed.
+	ReasonParse ScrapeErrorReason = "parse"
+	// ReasonTimeout means the request didn't complete within the modem's
+	// configured timeout.
+	ReasonTimeout ScrapeErrorReason = "timeout"
+	// ReasonAuth means the login or basic auth credentials were rejected.
+	ReasonAuth ScrapeErrorReason = "auth"
+)
+
+// ScrapeError wraps a Scrape failure with the ScrapeErrorReason it occurred
+// for.
+type ScrapeError struct {
+	Reason ScrapeErrorReason
+	Err    error
+}
+
+func (e *ScrapeError) Error() string { return e.Err.Error() }
+func (e *ScrapeError) Unwrap() error { return e.Err }
+
+// isTimeout reports whether err is a network timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// scrapeErrorReason returns the ScrapeErrorReason embedded in err, falling
+// back to ReasonHTTP for errors that weren't classified, e.g. an
+// unsupported modem model.
+func scrapeErrorReason(err error) ScrapeErrorReason {
+	var scrapeErr *ScrapeError
+	if errors.As(err, &scrapeErr) {
+		return scrapeErr.Reason
+	}
+	return ReasonHTTP
+}
+
+// ModemBackend fetches and parses the current status of a single modem.
+// Each supported Netgear model gets its own implementation, since the
+// DocsisStatus.htm layout and the set of available tables differ across
+// firmwares.
+type ModemBackend interface {
+	Scrape(ctx context.Context) (*ModemStatus, error)
+}
+
+// NewModemBackend returns the ModemBackend for modem's configured model.
+func NewModemBackend(modem Modem) (ModemBackend, error) {
+	switch strings.ToLower(modem.Model) {
+	case "", "cm1000":
+		return NewCM1000Backend(modem), nil
+	case "cm1100":
+		return NewCM1100Backend(modem), nil
+	case "cm2000":
+		return NewCM2000Backend(modem), nil
+	case "cm500":
+		return NewCM500Backend(modem), nil
+	case "cm600":
+		return NewCM600Backend(modem), nil
+	default:
+		return nil, fmt.Errorf("unsupported modem model %q", modem.Model)
+	}
+}
+
+// modemURL builds the URL of path on modem, honoring its configured scheme.
+func modemURL(modem Modem, path string) string {
+	return modem.Scheme + "://" + modem.Address + "/" + path
+}
+
+// newCollector returns a colly.Collector configured with modem's TLS and
+// timeout settings. If modem.LoginPath is set, it is POSTed with modem's
+// credentials to establish a session cookie, which colly's default cookie
+// jar then carries to every subsequent request made with the returned
+// collector; otherwise every request carries a basic auth header instead.
+func newCollector(modem Modem) (*colly.Collector, error) {
+	c := colly.NewCollector()
+	c.SetRequestTimeout(time.Duration(modem.TimeoutSeconds) * time.Second)
+	c.WithTransport(&http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: modem.InsecureSkipVerify},
+	})
+
+	if modem.LoginPath == "" {
+		c.OnRequest(func(r *colly.Request) {
+			r.Headers.Add("Authorization", "Basic "+basicAuth(modem.Username, modem.Password))
+		})
+		return c, nil
+	}
+
+	if err := c.Post(modemURL(modem, modem.LoginPath), map[string]string{
+		"username": modem.Username,
+		"password": modem.Password,
+	}); err != nil {
+		return nil, &ScrapeError{Reason: ReasonAuth, Err: fmt.Errorf("login failed: %w", err)}
+	}
+
+	return c, nil
+}
+
+// parseDownstreamRow parses a row of the #dsTable SC-QAM downstream table.
+func parseDownstreamRow(row *goquery.Selection) DownstreamChannel {
+	var ch DownstreamChannel
+	row.Find("td").Each(func(j int, col *goquery.Selection) {
+		text := strings.TrimSpace(col.Text())
+		switch j {
+		case 0:
+			ch.Channel = text
+		case 1:
+			ch.LockStatus = text
+		case 2:
+			ch.Modulation = text
+		case 3:
+			ch.ChannelID = text
+		case 4:
+			var freqHZ float64
+			fmt.Sscanf(text, "%f Hz", &freqHZ)
+			ch.Frequency = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
+		case 5:
+			fmt.Sscanf(text, "%f dBmV", &ch.PowerDBMV)
+		case 6:
+			fmt.Sscanf(text, "%f dB", &ch.SNRDB)
+		case 7:
+			fmt.Sscanf(text, "%f", &ch.CorrectableErrors)
+		case 8:
+			fmt.Sscanf(text, "%f", &ch.UncorrectableErrors)
+		}
+	})
+	return ch
+}
+
+// parseUpstreamRow parses a row of the #usTable SC-QAM upstream table.
+func parseUpstreamRow(row *goquery.Selection) UpstreamChannel {
+	var ch UpstreamChannel
+	row.Find("td").Each(func(j int, col *goquery.Selection) {
+		text := strings.TrimSpace(col.Text())
+		switch j {
+		case 0:
+			ch.Channel = text
+		case 1:
+			ch.LockStatus = text
+		case 2:
+			ch.ChannelType = text
+		case 3:
+			ch.ChannelID = text
+		case 4:
+			fmt.Sscanf(text, "%f Ksym/sec", &ch.SymbolRate)
+			ch.SymbolRate = ch.SymbolRate * 1000 // convert to sym/sec
+		case 5:
+			var freqHZ float64
+			fmt.Sscanf(text, "%f Hz", &freqHZ)
+			ch.Frequency = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
+		case 6:
+			fmt.Sscanf(text, "%f dBmV", &ch.PowerDBMV)
+		}
+	})
+	return ch
+}
+
+// parseDSOFDMRow parses a row of the DOCSIS 3.1 OFDM downstream channel table.
+func parseDSOFDMRow(row *goquery.Selection) DownstreamOFDMChannel {
+	var ch DownstreamOFDMChannel
+	row.Find("td").Each(func(j int, col *goquery.Selection) {
+		text := strings.TrimSpace(col.Text())
+		switch j {
+		case 0:
+			ch.Channel = text
+		case 1:
+			ch.LockStatus = text
+		case 2:
+			ch.Modulation = text
+		case 3:
+			ch.ChannelID = text
+		case 4:
+			ch.FFTType = text
+		case 5:
+			var freqHZ float64
+			fmt.Sscanf(text, "%f Hz", &freqHZ)
+			ch.Frequency = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
+		case 6:
+			fmt.Sscanf(text, "%f dBmV", &ch.PowerDBMV)
+		case 7:
+			fmt.Sscanf(text, "%f dBmV", &ch.PLCPowerDBMV)
+		case 8:
+			fmt.Sscanf(text, "%f dB", &ch.MERDB)
+		case 10:
+			fmt.Sscanf(text, "%f", &ch.UnerroredCodewords)
+		case 11:
+			fmt.Sscanf(text, "%f", &ch.CorrectableCodewords)
+		case 12:
+			fmt.Sscanf(text, "%f", &ch.UncorrectableCodewords)
+		}
+	})
+	return ch
+}
+
+// parseUSOFDMARow parses a row of the DOCSIS 3.1 OFDMA upstream channel table.
+func parseUSOFDMARow(row *goquery.Selection) UpstreamOFDMAChannel {
+	var ch UpstreamOFDMAChannel
+	row.Find("td").Each(func(j int, col *goquery.Selection) {
+		text := strings.TrimSpace(col.Text())
+		switch j {
+		case 0:
+			ch.Channel = text
+		case 1:
+			ch.LockStatus = text
+		case 2:
+			ch.ChannelType = text
+		case 3:
+			ch.ChannelID = text
+		case 4:
+			var freqHZ float64
+			fmt.Sscanf(text, "%f Hz", &freqHZ)
+			ch.Frequency = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
+		case 5:
+			fmt.Sscanf(text, "%f dBmV", &ch.PowerDBMV)
+		}
+	})
+	return ch
+}
+
+// parseEventLogSeverity returns the severity column of a row of the event
+// log table.
+func parseEventLogSeverity(row *goquery.Selection) string {
+	var severity string
+	row.Find("td").Each(func(j int, col *goquery.Selection) {
+		if j == 1 {
+			severity = strings.TrimSpace(col.Text())
+		}
+	})
+	return severity
+}
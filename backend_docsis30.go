@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// DOCSIS30Backend scrapes the SC-QAM downstream/upstream tables of
+// DocsisStatus.htm. It has no DOCSIS 3.1 OFDM/OFDMA channels or event log
+// page, matching DOCSIS 3.0-only modems such as the CM500 and CM600.
+type DOCSIS30Backend struct {
+	modem Modem
+}
+
+// NewCM500Backend returns a ModemBackend for the Netgear CM500.
+func NewCM500Backend(modem Modem) *DOCSIS30Backend {
+	return &DOCSIS30Backend{modem: modem}
+}
+
+// NewCM600Backend returns a ModemBackend for the Netgear CM600.
+func NewCM600Backend(modem Modem) *DOCSIS30Backend {
+	return &DOCSIS30Backend{modem: modem}
+}
+
+// Scrape fetches and parses DocsisStatus.htm.
+func (b *DOCSIS30Backend) Scrape(ctx context.Context) (*ModemStatus, error) {
+	status := &ModemStatus{}
+
+	c, err := newCollector(b.modem)
+	if err != nil {
+		return status, err
+	}
+
+	var scrapeErr error
+	c.OnError(func(r *colly.Response, err error) {
+		reason := ReasonHTTP
+		switch {
+		case isTimeout(err):
+			reason = ReasonTimeout
+		case r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden:
+			reason = ReasonAuth
+		}
+		scrapeErr = &ScrapeError{Reason: reason, Err: fmt.Errorf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))}
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		status.StatusCode = r.StatusCode
+	})
+
+	c.OnHTML(`#dsTable tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.DownstreamChannels = append(status.DownstreamChannels, parseDownstreamRow(row))
+		})
+	})
+
+	c.OnHTML(`#usTable tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.UpstreamChannels = append(status.UpstreamChannels, parseUpstreamRow(row))
+		})
+	})
+
+	c.Visit(modemURL(b.modem, "DocsisStatus.htm"))
+
+	if scrapeErr == nil && len(status.DownstreamChannels) == 0 && len(status.UpstreamChannels) == 0 {
+		scrapeErr = &ScrapeError{Reason: ReasonParse, Err: fmt.Errorf("no channels parsed from DocsisStatus.htm")}
+	}
+
+	return status, scrapeErr
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDOCSIS30BackendScrape(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/DocsisStatus.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/docsis30_status.html")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	modem := Modem{
+		Address:        strings.TrimPrefix(server.URL, "http://"),
+		Username:       "admin",
+		Password:       "foobaz",
+		Scheme:         "http",
+		TimeoutSeconds: 5,
+	}
+
+	status, err := NewCM600Backend(modem).Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	wantDS := []DownstreamChannel{
+		{Channel: "1", LockStatus: "Locked", Modulation: "256QAM", ChannelID: "5", Frequency: "555.00 MHz", PowerDBMV: 1.2, SNRDB: 40.1, CorrectableErrors: 12, UncorrectableErrors: 0},
+		{Channel: "2", LockStatus: "Locked", Modulation: "256QAM", ChannelID: "6", Frequency: "561.00 MHz", PowerDBMV: 0.9, SNRDB: 39.8, CorrectableErrors: 5, UncorrectableErrors: 1},
+	}
+	if diff := cmp.Diff(wantDS, status.DownstreamChannels); diff != "" {
+		t.Errorf("downstream channels differ (-want +got): %s", diff)
+	}
+
+	wantUS := []UpstreamChannel{
+		{Channel: "1", LockStatus: "Locked", ChannelType: "ATDMA", ChannelID: "2", Frequency: "35.60 MHz", PowerDBMV: 45.0, SymbolRate: 5120000},
+	}
+	if diff := cmp.Diff(wantUS, status.UpstreamChannels); diff != "" {
+		t.Errorf("upstream channels differ (-want +got): %s", diff)
+	}
+
+	if status.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", status.StatusCode, http.StatusOK)
+	}
+}
+
+func TestDOCSIS30BackendScrapeHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	modem := Modem{
+		Address:        strings.TrimPrefix(server.URL, "http://"),
+		Username:       "admin",
+		Password:       "foobaz",
+		Scheme:         "http",
+		TimeoutSeconds: 5,
+	}
+
+	_, err := NewCM500Backend(modem).Scrape(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+	if reason := scrapeErrorReason(err); reason != ReasonHTTP {
+		t.Errorf("scrapeErrorReason() = %q, want %q", reason, ReasonHTTP)
+	}
+}
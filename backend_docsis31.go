@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// DOCSIS31Backend scrapes the SC-QAM downstream/upstream tables of
+// DocsisStatus.htm plus the DOCSIS 3.1 OFDM downstream and OFDMA upstream
+// channel tables and the event log page, matching the CM1000, CM1100 and
+// CM2000.
+type DOCSIS31Backend struct {
+	modem Modem
+}
+
+// NewCM1000Backend returns a ModemBackend for the Netgear CM1000.
+func NewCM1000Backend(modem Modem) *DOCSIS31Backend {
+	return &DOCSIS31Backend{modem: modem}
+}
+
+// NewCM1100Backend returns a ModemBackend for the Netgear CM1100.
+func NewCM1100Backend(modem Modem) *DOCSIS31Backend {
+	return &DOCSIS31Backend{modem: modem}
+}
+
+// NewCM2000Backend returns a ModemBackend for the Netgear CM2000.
+func NewCM2000Backend(modem Modem) *DOCSIS31Backend {
+	return &DOCSIS31Backend{modem: modem}
+}
+
+// Scrape fetches and parses DocsisStatus.htm and the event log page.
+func (b *DOCSIS31Backend) Scrape(ctx context.Context) (*ModemStatus, error) {
+	status := &ModemStatus{
+		EventLogEntries: make(map[string]float64),
+	}
+
+	c, err := newCollector(b.modem)
+	if err != nil {
+		return status, err
+	}
+
+	statusURL := modemURL(b.modem, "DocsisStatus.htm")
+	eventLogURL := modemURL(b.modem, b.modem.EventLogPath)
+
+	// scrapeErr only tracks failures fetching statusURL: a failure fetching
+	// eventLogURL alone (e.g. it 404s on a given unit) shouldn't fail the
+	// overall scrape, since DocsisStatus.htm's channel metrics are still
+	// valid. The event log metrics just reflect no entries in that case,
+	// same as a unit with an empty log.
+	var scrapeErr error
+	c.OnError(func(r *colly.Response, err error) {
+		if r.Request.URL.String() == eventLogURL {
+			return
+		}
+
+		reason := ReasonHTTP
+		switch {
+		case isTimeout(err):
+			reason = ReasonTimeout
+		case r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden:
+			reason = ReasonAuth
+		}
+		scrapeErr = &ScrapeError{Reason: reason, Err: fmt.Errorf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))}
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		status.StatusCode = r.StatusCode
+	})
+
+	c.OnHTML(`#dsTable tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.DownstreamChannels = append(status.DownstreamChannels, parseDownstreamRow(row))
+		})
+	})
+
+	c.OnHTML(`#usTable tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.UpstreamChannels = append(status.UpstreamChannels, parseUpstreamRow(row))
+		})
+	})
+
+	c.OnHTML(`#`+b.modem.DSOFDMTableID+` tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.DownstreamOFDMChannels = append(status.DownstreamOFDMChannels, parseDSOFDMRow(row))
+		})
+	})
+
+	c.OnHTML(`#`+b.modem.USOFDMATableID+` tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			status.UpstreamOFDMAChannels = append(status.UpstreamOFDMAChannels, parseUSOFDMARow(row))
+		})
+	})
+
+	c.OnHTML(`#eventLogTable tbody`, func(elem *colly.HTMLElement) {
+		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
+			if i == 0 {
+				return // header row
+			}
+			if severity := parseEventLogSeverity(row); severity != "" {
+				status.EventLogEntries[severity]++
+			}
+		})
+	})
+
+	c.Visit(statusURL)
+	c.Visit(eventLogURL)
+
+	if scrapeErr == nil && len(status.DownstreamChannels) == 0 && len(status.UpstreamChannels) == 0 {
+		scrapeErr = &ScrapeError{Reason: ReasonParse, Err: fmt.Errorf("no channels parsed from DocsisStatus.htm")}
+	}
+
+	return status, scrapeErr
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newDOCSIS31TestModem(t *testing.T, mux *http.ServeMux) Modem {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return Modem{
+		Address:        strings.TrimPrefix(server.URL, "http://"),
+		Username:       "admin",
+		Password:       "foobaz",
+		Scheme:         "http",
+		TimeoutSeconds: 5,
+		DSOFDMTableID:  "dsOfdmTable",
+		USOFDMATableID: "usOfdmaTable",
+		EventLogPath:   "EventLog.htm",
+	}
+}
+
+func TestDOCSIS31BackendScrape(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/DocsisStatus.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/docsis31_status.html")
+	})
+	mux.HandleFunc("/EventLog.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/docsis31_eventlog.html")
+	})
+	modem := newDOCSIS31TestModem(t, mux)
+
+	status, err := NewCM1000Backend(modem).Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	wantDSOFDM := []DownstreamOFDMChannel{
+		{Channel: "1", LockStatus: "Locked", Modulation: "256QAM", ChannelID: "33", FFTType: "4K", Frequency: "514.00 MHz", PowerDBMV: 2.1, PLCPowerDBMV: 1.8, MERDB: 42.0, UnerroredCodewords: 1000, CorrectableCodewords: 20, UncorrectableCodewords: 0},
+	}
+	if diff := cmp.Diff(wantDSOFDM, status.DownstreamOFDMChannels); diff != "" {
+		t.Errorf("downstream OFDM channels differ (-want +got): %s", diff)
+	}
+
+	wantUSOFDMA := []UpstreamOFDMAChannel{
+		{Channel: "1", LockStatus: "Locked", ChannelType: "OFDMA", ChannelID: "9", Frequency: "30.00 MHz", PowerDBMV: 44.0},
+	}
+	if diff := cmp.Diff(wantUSOFDMA, status.UpstreamOFDMAChannels); diff != "" {
+		t.Errorf("upstream OFDMA channels differ (-want +got): %s", diff)
+	}
+
+	wantEventLog := map[string]float64{"Critical": 2, "Warning": 1}
+	if diff := cmp.Diff(wantEventLog, status.EventLogEntries); diff != "" {
+		t.Errorf("event log entries differ (-want +got): %s", diff)
+	}
+}
+
+// TestDOCSIS31BackendScrapeEventLogFailureDoesNotFailScrape verifies that a
+// failure fetching the event log page alone doesn't fail the overall scrape,
+// since DocsisStatus.htm's channel metrics are still valid.
+func TestDOCSIS31BackendScrapeEventLogFailureDoesNotFailScrape(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/DocsisStatus.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/docsis31_status.html")
+	})
+	mux.HandleFunc("/EventLog.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	modem := newDOCSIS31TestModem(t, mux)
+
+	status, err := NewCM1000Backend(modem).Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+	if len(status.DownstreamChannels) == 0 {
+		t.Error("expected downstream channels to still be parsed")
+	}
+	if len(status.EventLogEntries) != 0 {
+		t.Errorf("expected no event log entries, got %v", status.EventLogEntries)
+	}
+}
+
+func TestDOCSIS31BackendScrapeStatusFailure(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/DocsisStatus.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/EventLog.htm", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/docsis31_eventlog.html")
+	})
+	modem := newDOCSIS31TestModem(t, mux)
+
+	_, err := NewCM1000Backend(modem).Scrape(context.Background())
+	if err == nil {
+		t.Fatal("expected an error when DocsisStatus.htm fails")
+	}
+	if reason := scrapeErrorReason(err); reason != ReasonHTTP {
+		t.Errorf("scrapeErrorReason() = %q, want %q", reason, ReasonHTTP)
+	}
+}
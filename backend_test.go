@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/go-cmp/cmp"
+)
+
+// firstRow parses html and returns the second <tr> (skipping the header row)
+// of its first table, as the real OnHTML handlers do.
+func firstRow(t *testing.T, html string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to parse fixture HTML: %v", err)
+	}
+	return doc.Find("tr").Eq(1)
+}
+
+func TestParseDownstreamRow(t *testing.T) {
+	row := firstRow(t, `<table><tbody>
+		<tr><td>Channel</td><td>Lock Status</td><td>Modulation</td><td>Channel ID</td><td>Frequency</td><td>Power</td><td>SNR</td><td>Correctable</td><td>Uncorrectable</td></tr>
+		<tr><td>1</td><td>Locked</td><td>256QAM</td><td>5</td><td>555000000 Hz</td><td>1.2 dBmV</td><td>40.1 dB</td><td>12</td><td>3</td></tr>
+	</tbody></table>`)
+
+	want := DownstreamChannel{
+		Channel:             "1",
+		LockStatus:          "Locked",
+		Modulation:          "256QAM",
+		ChannelID:           "5",
+		Frequency:           "555.00 MHz",
+		PowerDBMV:           1.2,
+		SNRDB:               40.1,
+		CorrectableErrors:   12,
+		UncorrectableErrors: 3,
+	}
+	if diff := cmp.Diff(want, parseDownstreamRow(row)); diff != "" {
+		t.Errorf("parseDownstreamRow() differs (-want +got): %s", diff)
+	}
+}
+
+func TestParseUpstreamRow(t *testing.T) {
+	row := firstRow(t, `<table><tbody>
+		<tr><td>Channel</td><td>Lock Status</td><td>Channel Type</td><td>Channel ID</td><td>Symbol Rate</td><td>Frequency</td><td>Power</td></tr>
+		<tr><td>1</td><td>Locked</td><td>ATDMA</td><td>2</td><td>5120 Ksym/sec</td><td>35600000 Hz</td><td>45.0 dBmV</td></tr>
+	</tbody></table>`)
+
+	want := UpstreamChannel{
+		Channel:     "1",
+		LockStatus:  "Locked",
+		ChannelType: "ATDMA",
+		ChannelID:   "2",
+		Frequency:   "35.60 MHz",
+		PowerDBMV:   45.0,
+		SymbolRate:  5120000,
+	}
+	if diff := cmp.Diff(want, parseUpstreamRow(row)); diff != "" {
+		t.Errorf("parseUpstreamRow() differs (-want +got): %s", diff)
+	}
+}
+
+func TestParseDSOFDMRow(t *testing.T) {
+	row := firstRow(t, `<table><tbody>
+		<tr><td>Channel</td><td>Lock Status</td><td>Modulation</td><td>Channel ID</td><td>FFT Type</td><td>Frequency</td><td>Power</td><td>PLC Power</td><td>MER</td><td>Active Subcarriers</td><td>Unerrored</td><td>Correctable</td><td>Uncorrectable</td></tr>
+		<tr><td>1</td><td>Locked</td><td>256QAM</td><td>33</td><td>4K</td><td>514000000 Hz</td><td>2.1 dBmV</td><td>1.8 dBmV</td><td>42.0 dB</td><td>148-1684</td><td>1000</td><td>20</td><td>5</td></tr>
+	</tbody></table>`)
+
+	want := DownstreamOFDMChannel{
+		Channel:                "1",
+		LockStatus:             "Locked",
+		Modulation:             "256QAM",
+		ChannelID:              "33",
+		FFTType:                "4K",
+		Frequency:              "514.00 MHz",
+		PowerDBMV:              2.1,
+		PLCPowerDBMV:           1.8,
+		MERDB:                  42.0,
+		UnerroredCodewords:     1000,
+		CorrectableCodewords:   20,
+		UncorrectableCodewords: 5,
+	}
+	if diff := cmp.Diff(want, parseDSOFDMRow(row)); diff != "" {
+		t.Errorf("parseDSOFDMRow() differs (-want +got): %s", diff)
+	}
+}
+
+func TestParseUSOFDMARow(t *testing.T) {
+	row := firstRow(t, `<table><tbody>
+		<tr><td>Channel</td><td>Lock Status</td><td>Channel Type</td><td>Channel ID</td><td>Frequency</td><td>Power</td></tr>
+		<tr><td>1</td><td>Locked</td><td>OFDMA</td><td>9</td><td>30000000 Hz</td><td>44.0 dBmV</td></tr>
+	</tbody></table>`)
+
+	want := UpstreamOFDMAChannel{
+		Channel:     "1",
+		LockStatus:  "Locked",
+		ChannelType: "OFDMA",
+		ChannelID:   "9",
+		Frequency:   "30.00 MHz",
+		PowerDBMV:   44.0,
+	}
+	if diff := cmp.Diff(want, parseUSOFDMARow(row)); diff != "" {
+		t.Errorf("parseUSOFDMARow() differs (-want +got): %s", diff)
+	}
+}
+
+func TestParseEventLogSeverity(t *testing.T) {
+	row := firstRow(t, `<table><tbody>
+		<tr><td>Time</td><td>Priority</td><td>Description</td></tr>
+		<tr><td>2026-07-01</td><td>Critical</td><td>No Ranging Response received</td></tr>
+	</tbody></table>`)
+
+	if got, want := parseEventLogSeverity(row), "Critical"; got != want {
+		t.Errorf("parseEventLogSeverity() = %q, want %q", got, want)
+	}
+}
+
+func TestNewModemBackendUnsupportedModel(t *testing.T) {
+	if _, err := NewModemBackend(Modem{Model: "cm9999"}); err == nil {
+		t.Error("expected an error for an unsupported modem model")
+	}
+}
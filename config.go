@@ -8,22 +8,54 @@ import (
 	yaml "gopkg.in/yaml.v2"
 )
 
-// Modem represents the address of the modem and its admin credentials.
+// Modem represents the address of a single cable modem and its admin
+// credentials. Name is optional; when unset it defaults to Address. Name is
+// used to select the modem via /probe?target=<name> and as the "modem"
+// label value on every metric scraped from it.
 type Modem struct {
+	Name     string `yaml:"name"`
 	Address  string `yaml:"address"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+
+	// Model selects the ModemBackend used to scrape this modem, e.g.
+	// "cm500", "cm600", "cm1000", "cm1100" or "cm2000". Defaults to "cm1000".
+	Model string `yaml:"model"`
+
+	// DSOFDMTableID and USOFDMATableID are the HTML element IDs of the
+	// DOCSIS 3.1 OFDM downstream and OFDMA upstream channel tables on the
+	// status page. These vary across Netgear firmwares.
+	DSOFDMTableID  string `yaml:"ds_ofdm_table_id"`
+	USOFDMATableID string `yaml:"us_ofdma_table_id"`
+
+	// EventLogPath is the path of the modem's event log page.
+	EventLogPath string `yaml:"event_log_path"`
+
+	// Scheme is the protocol used to reach the modem: "http" (default) or
+	// "https".
+	Scheme string `yaml:"scheme"`
+	// InsecureSkipVerify disables TLS certificate verification when Scheme
+	// is "https".
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+	// TimeoutSeconds bounds how long a single scrape request may take.
+	// Defaults to 10.
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+	// LoginPath, if set, is POSTed with the modem's username and password to
+	// obtain a session cookie before the status and event log pages are
+	// requested, instead of sending them as basic auth credentials.
+	LoginPath string `yaml:"login_path"`
 }
 
-// Telemetry represents the exporter's listen address and metrics URI path.
+// Telemetry represents the exporter's listen address and metrics URI paths.
 type Telemetry struct {
 	ListenAddress string `yaml:"listen_address"`
 	MetricsPath   string `yaml:"metrics_path"`
+	ProbePath     string `yaml:"probe_path"`
 }
 
 // Config represents the yaml config file structure.
 type Config struct {
-	Modem     Modem     `yaml:"modem"`
+	Modems    []Modem   `yaml:"modems"`
 	Telemetry Telemetry `yaml:"telemetry"`
 }
 
@@ -37,13 +69,10 @@ func NewConfigFromFile(path string) (*Config, error) {
 
 	// Setup default config.
 	config := Config{
-		Modem: Modem{
-			Address:  "192.168.100.1",
-			Username: "admin",
-		},
 		Telemetry: Telemetry{
 			ListenAddress: ":9527",
 			MetricsPath:   "/metrics",
+			ProbePath:     "/probe",
 		},
 	}
 
@@ -51,9 +80,51 @@ func NewConfigFromFile(path string) (*Config, error) {
 		return nil, errors.Wrap(err, "unable to parse config YAML")
 	}
 
-	if config.Modem.Password == "" {
-		return nil, fmt.Errorf("modem password isn't set in config")
+	if len(config.Modems) == 0 {
+		return nil, fmt.Errorf("no modems configured")
+	}
+
+	for i := range config.Modems {
+		m := &config.Modems[i]
+		if m.Address == "" {
+			m.Address = "192.168.100.1"
+		}
+		if m.Username == "" {
+			m.Username = "admin"
+		}
+		if m.Password == "" {
+			return nil, fmt.Errorf("modem %q: password isn't set in config", m.Address)
+		}
+		if m.Name == "" {
+			m.Name = m.Address
+		}
+		if m.DSOFDMTableID == "" {
+			m.DSOFDMTableID = "dsOfdmTable"
+		}
+		if m.USOFDMATableID == "" {
+			m.USOFDMATableID = "usOfdmaTable"
+		}
+		if m.EventLogPath == "" {
+			m.EventLogPath = "EventLog.htm"
+		}
+		if m.Scheme == "" {
+			m.Scheme = "http"
+		}
+		if m.TimeoutSeconds == 0 {
+			m.TimeoutSeconds = 10
+		}
 	}
 
 	return &config, nil
 }
+
+// FindModem returns the configured modem whose name or address matches
+// target, and reports whether one was found.
+func (c *Config) FindModem(target string) (Modem, bool) {
+	for _, m := range c.Modems {
+		if m.Name == target || m.Address == target {
+			return m, true
+		}
+	}
+	return Modem{}, false
+}
@@ -8,14 +8,23 @@ import (
 
 func TestNewConfigFromFile(t *testing.T) {
 	want := &Config{
-		Modem: Modem{
-			Address:  "192.168.100.1",
-			Username: "admin",
-			Password: "foobaz",
+		Modems: []Modem{
+			{
+				Name:           "192.168.100.1",
+				Address:        "192.168.100.1",
+				Username:       "admin",
+				Password:       "foobaz",
+				DSOFDMTableID:  "dsOfdmTable",
+				USOFDMATableID: "usOfdmaTable",
+				EventLogPath:   "EventLog.htm",
+				Scheme:         "http",
+				TimeoutSeconds: 10,
+			},
 		},
 		Telemetry: Telemetry{
 			ListenAddress: ":9527",
 			MetricsPath:   "/metrics",
+			ProbePath:     "/probe",
 		},
 	}
 
@@ -27,5 +36,100 @@ func TestNewConfigFromFile(t *testing.T) {
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("config differs (-want, +got): %s", diff)
 	}
+}
+
+func TestNewConfigFromFileMultipleModems(t *testing.T) {
+	want := &Config{
+		Modems: []Modem{
+			{
+				Name:           "basement",
+				Address:        "192.168.100.1",
+				Username:       "admin",
+				Password:       "foobaz",
+				DSOFDMTableID:  "dsOfdmTable",
+				USOFDMATableID: "usOfdmaTable",
+				EventLogPath:   "EventLog.htm",
+				Scheme:         "http",
+				TimeoutSeconds: 10,
+			},
+			{
+				Name:           "attic",
+				Address:        "192.168.100.2",
+				Username:       "root",
+				Password:       "hunter2",
+				DSOFDMTableID:  "dsOfdmTable",
+				USOFDMATableID: "usOfdmaTable",
+				EventLogPath:   "EventLog.htm",
+				Scheme:         "http",
+				TimeoutSeconds: 10,
+			},
+		},
+		Telemetry: Telemetry{
+			ListenAddress: ":9527",
+			MetricsPath:   "/metrics",
+			ProbePath:     "/probe",
+		},
+	}
+
+	got, err := NewConfigFromFile("testdata/multi.yml")
+	if err != nil {
+		t.Error(err)
+	}
 
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("config differs (-want, +got): %s", diff)
+	}
+}
+
+func TestNewConfigFromFileTLS(t *testing.T) {
+	want := &Config{
+		Modems: []Modem{
+			{
+				Name:               "attic",
+				Address:            "192.168.100.1",
+				Username:           "admin",
+				Password:           "foobaz",
+				DSOFDMTableID:      "dsOfdmTable",
+				USOFDMATableID:     "usOfdmaTable",
+				EventLogPath:       "EventLog.htm",
+				Scheme:             "https",
+				InsecureSkipVerify: true,
+				TimeoutSeconds:     30,
+				LoginPath:          "/goform/login",
+			},
+		},
+		Telemetry: Telemetry{
+			ListenAddress: ":9527",
+			MetricsPath:   "/metrics",
+			ProbePath:     "/probe",
+		},
+	}
+
+	got, err := NewConfigFromFile("testdata/tls.yml")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("config differs (-want, +got): %s", diff)
+	}
+}
+
+func TestConfigFindModem(t *testing.T) {
+	config, err := NewConfigFromFile("testdata/multi.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := config.FindModem("attic"); !ok {
+		t.Error("expected to find modem by name")
+	}
+
+	if _, ok := config.FindModem("192.168.100.1"); !ok {
+		t.Error("expected to find modem by address")
+	}
+
+	if _, ok := config.FindModem("nope"); ok {
+		t.Error("expected not to find unknown modem")
+	}
 }
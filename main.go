@@ -1,19 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/base64"
 	"flag"
 	"fmt"
-	"log"
+	"html/template"
+	"log/slog"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
-	"github.com/PuerkitoBio/goquery"
-	"github.com/gocolly/colly"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 )
 
 const namespace = "netgear_cm"
@@ -26,15 +30,21 @@ var (
 	buildDate string
 )
 
-// Exporter represents an instance of the Netgear cable modem exporter.
+// Exporter represents an instance of the Netgear cable modem exporter,
+// scoped to a single modem. It translates the ModemStatus returned by its
+// ModemBackend into Prometheus metrics.
 type Exporter struct {
-	url, authHeaderValue string
+	modem   Modem
+	backend ModemBackend
+	logger  *slog.Logger
 
 	mu sync.Mutex
 
 	// Exporter metrics.
-	totalScrapes prometheus.Counter
-	scrapeErrors prometheus.Counter
+	totalScrapes   prometheus.Counter
+	scrapeErrors   *prometheus.CounterVec
+	scrapeDuration prometheus.ObserverVec
+	channels       *prometheus.GaugeVec
 
 	// Downstream metrics.
 	dsChannelSNR               *prometheus.Desc
@@ -45,6 +55,20 @@ type Exporter struct {
 	// Upstream metrics.
 	usChannelPower      *prometheus.Desc
 	usChannelSymbolRate *prometheus.Desc
+
+	// DOCSIS 3.1 downstream OFDM channel metrics.
+	dsOfdmChannelPower                  *prometheus.Desc
+	dsOfdmChannelPLCPower               *prometheus.Desc
+	dsOfdmChannelMER                    *prometheus.Desc
+	dsOfdmChannelUnerroredCodewords     *prometheus.Desc
+	dsOfdmChannelCorrectableCodewords   *prometheus.Desc
+	dsOfdmChannelUncorrectableCodewords *prometheus.Desc
+
+	// DOCSIS 3.1 upstream OFDMA channel metrics.
+	usOfdmaChannelPower *prometheus.Desc
+
+	// Event log metrics.
+	eventLogEntries *prometheus.Desc
 }
 
 // basicAuth returns the base64 encoding of the username and password
@@ -54,30 +78,31 @@ func basicAuth(username, password string) string {
 	return base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
-// NewExporter returns an instance of Exporter configured with the modem's
-// address, admin username and password.
-func NewExporter(addr, username, password string) *Exporter {
+// NewExporter returns an instance of Exporter that scrapes modem through
+// backend, recording its total scrape count against totalScrapes and, per
+// scrape, its error count against scrapeErrors (labeled by failure reason),
+// its duration against scrapeDuration (labeled by result) and its parsed
+// channel counts against channels (labeled by direction). Each scrape is
+// also logged against logger.
+func NewExporter(modem Modem, backend ModemBackend, totalScrapes prometheus.Counter, scrapeErrors *prometheus.CounterVec, scrapeDuration prometheus.ObserverVec, channels *prometheus.GaugeVec, logger *slog.Logger) *Exporter {
 	var (
-		dsLabelNames = []string{"channel", "lock_status", "modulation", "channel_id", "frequency"}
-		usLabelNames = []string{"channel", "lock_status", "channel_type", "channel_id", "frequency"}
+		dsLabelNames       = []string{"modem", "channel", "lock_status", "modulation", "channel_id", "frequency"}
+		usLabelNames       = []string{"modem", "channel", "lock_status", "channel_type", "channel_id", "frequency"}
+		dsOfdmLabelNames   = []string{"modem", "channel", "lock_status", "modulation", "channel_id", "fft_type", "frequency"}
+		usOfdmaLabelNames  = []string{"modem", "channel", "lock_status", "channel_type", "channel_id", "frequency"}
+		eventLogLabelNames = []string{"modem", "severity"}
 	)
 
 	return &Exporter{
-		// Modem access details.
-		url:             "http://" + addr + "/DocsisStatus.htm",
-		authHeaderValue: "Basic " + basicAuth(username, password),
+		modem:   modem,
+		backend: backend,
+		logger:  logger,
 
 		// Collection metrics.
-		totalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: namespace,
-			Name:      "status_scrapes_total",
-			Help:      "Total number of scrapes of the modem status page.",
-		}),
-		scrapeErrors: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: namespace,
-			Name:      "status_scrape_errors_total",
-			Help:      "Total number of failed scrapes of the modem status page.",
-		}),
+		totalScrapes:   totalScrapes,
+		scrapeErrors:   scrapeErrors,
+		scrapeDuration: scrapeDuration,
+		channels:       channels,
 
 		// Downstream metrics.
 		dsChannelSNR: prometheus.NewDesc(
@@ -112,6 +137,52 @@ func NewExporter(addr, username, password string) *Exporter {
 			"Upstream channel symbol rate per second",
 			usLabelNames, nil,
 		),
+
+		// DOCSIS 3.1 downstream OFDM channel metrics.
+		dsOfdmChannelPower: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "power_dbmv"),
+			"Downstream OFDM channel power in dBmV.",
+			dsOfdmLabelNames, nil,
+		),
+		dsOfdmChannelPLCPower: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "plc_power_dbmv"),
+			"Downstream OFDM channel PLC power in dBmV.",
+			dsOfdmLabelNames, nil,
+		),
+		dsOfdmChannelMER: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "mer_db"),
+			"Downstream OFDM channel modulation error ratio in dB.",
+			dsOfdmLabelNames, nil,
+		),
+		dsOfdmChannelUnerroredCodewords: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "unerrored_codewords_total"),
+			"Downstream OFDM channel unerrored codewords.",
+			dsOfdmLabelNames, nil,
+		),
+		dsOfdmChannelCorrectableCodewords: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "correctable_codewords_total"),
+			"Downstream OFDM channel correctable codewords.",
+			dsOfdmLabelNames, nil,
+		),
+		dsOfdmChannelUncorrectableCodewords: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "downstream_ofdm_channel", "uncorrectable_codewords_total"),
+			"Downstream OFDM channel uncorrectable codewords.",
+			dsOfdmLabelNames, nil,
+		),
+
+		// DOCSIS 3.1 upstream OFDMA channel metrics.
+		usOfdmaChannelPower: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "upstream_ofdma_channel", "power_dbmv"),
+			"Upstream OFDMA channel power in dBmV.",
+			usOfdmaLabelNames, nil,
+		),
+
+		// Event log metrics.
+		eventLogEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "event_log", "entries_total"),
+			"Number of entries present in the modem's event log, by severity.",
+			eventLogLabelNames, nil,
+		),
 	}
 }
 
@@ -119,7 +190,9 @@ func NewExporter(addr, username, password string) *Exporter {
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	// Exporter metrics.
 	ch <- e.totalScrapes.Desc()
-	ch <- e.scrapeErrors.Desc()
+	e.scrapeErrors.Describe(ch)
+	e.scrapeDuration.Describe(ch)
+	e.channels.Describe(ch)
 	// Downstream metrics.
 	ch <- e.dsChannelSNR
 	ch <- e.dsChannelPower
@@ -128,138 +201,272 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	// Upstream metrics.
 	ch <- e.usChannelPower
 	ch <- e.usChannelSymbolRate
+	// DOCSIS 3.1 downstream OFDM metrics.
+	ch <- e.dsOfdmChannelPower
+	ch <- e.dsOfdmChannelPLCPower
+	ch <- e.dsOfdmChannelMER
+	ch <- e.dsOfdmChannelUnerroredCodewords
+	ch <- e.dsOfdmChannelCorrectableCodewords
+	ch <- e.dsOfdmChannelUncorrectableCodewords
+	// DOCSIS 3.1 upstream OFDMA metrics.
+	ch <- e.usOfdmaChannelPower
+	// Event log metrics.
+	ch <- e.eventLogEntries
 }
 
 // Collect runs our scrape loop returning each Prometheus metric.
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.totalScrapes.Inc()
 
-	c := colly.NewCollector()
+	e.mu.Lock()
+	defer e.mu.Unlock()
 
-	// OnRequest callback adds basic auth header.
-	c.OnRequest(func(r *colly.Request) {
-		r.Headers.Add("Authorization", e.authHeaderValue)
-	})
+	start := time.Now()
+	status, err := e.backend.Scrape(context.Background())
+	duration := time.Since(start)
+	result := "success"
+	if err != nil {
+		reason := scrapeErrorReason(err)
+		result = string(reason)
+		e.scrapeErrors.WithLabelValues(result).Inc()
+		e.logger.Error("scrape failed",
+			"modem", e.modem.Name,
+			"duration_ms", duration.Milliseconds(),
+			"reason", reason,
+			"error", err,
+		)
+	} else {
+		e.logger.Info("scrape succeeded",
+			"modem", e.modem.Name,
+			"duration_ms", duration.Milliseconds(),
+			"status_code", status.StatusCode,
+			"ds_channels", len(status.DownstreamChannels)+len(status.DownstreamOFDMChannels),
+			"us_channels", len(status.UpstreamChannels)+len(status.UpstreamOFDMAChannels),
+		)
+	}
+	e.scrapeDuration.WithLabelValues(result).Observe(duration.Seconds())
+
+	e.channels.WithLabelValues("downstream").Set(float64(len(status.DownstreamChannels)))
+	e.channels.WithLabelValues("upstream").Set(float64(len(status.UpstreamChannels)))
+	e.channels.WithLabelValues("downstream_ofdm").Set(float64(len(status.DownstreamOFDMChannels)))
+	e.channels.WithLabelValues("upstream_ofdma").Set(float64(len(status.UpstreamOFDMAChannels)))
+
+	for _, dsChan := range status.DownstreamChannels {
+		labels := []string{e.modem.Name, dsChan.Channel, dsChan.LockStatus, dsChan.Modulation, dsChan.ChannelID, dsChan.Frequency}
+
+		ch <- prometheus.MustNewConstMetric(e.dsChannelSNR, prometheus.GaugeValue, dsChan.SNRDB, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsChannelPower, prometheus.GaugeValue, dsChan.PowerDBMV, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsChannelCorrectableErrs, prometheus.CounterValue, dsChan.CorrectableErrors, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsChannelUncorrectableErrs, prometheus.CounterValue, dsChan.UncorrectableErrors, labels...)
+	}
+
+	for _, usChan := range status.UpstreamChannels {
+		labels := []string{e.modem.Name, usChan.Channel, usChan.LockStatus, usChan.ChannelType, usChan.ChannelID, usChan.Frequency}
+
+		ch <- prometheus.MustNewConstMetric(e.usChannelPower, prometheus.GaugeValue, usChan.PowerDBMV, labels...)
+		ch <- prometheus.MustNewConstMetric(e.usChannelSymbolRate, prometheus.GaugeValue, usChan.SymbolRate, labels...)
+	}
 
-	// OnError callback counts any errors that occur during scraping.
-	c.OnError(func(r *colly.Response, err error) {
-		log.Printf("scrape failed: %d %s", r.StatusCode, http.StatusText(r.StatusCode))
-		e.scrapeErrors.Inc()
-	})
+	for _, dsChan := range status.DownstreamOFDMChannels {
+		labels := []string{e.modem.Name, dsChan.Channel, dsChan.LockStatus, dsChan.Modulation, dsChan.ChannelID, dsChan.FFTType, dsChan.Frequency}
+
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelPower, prometheus.GaugeValue, dsChan.PowerDBMV, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelPLCPower, prometheus.GaugeValue, dsChan.PLCPowerDBMV, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelMER, prometheus.GaugeValue, dsChan.MERDB, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelUnerroredCodewords, prometheus.CounterValue, dsChan.UnerroredCodewords, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelCorrectableCodewords, prometheus.CounterValue, dsChan.CorrectableCodewords, labels...)
+		ch <- prometheus.MustNewConstMetric(e.dsOfdmChannelUncorrectableCodewords, prometheus.CounterValue, dsChan.UncorrectableCodewords, labels...)
+	}
+
+	for _, usChan := range status.UpstreamOFDMAChannels {
+		labels := []string{e.modem.Name, usChan.Channel, usChan.LockStatus, usChan.ChannelType, usChan.ChannelID, usChan.Frequency}
+
+		ch <- prometheus.MustNewConstMetric(e.usOfdmaChannelPower, prometheus.GaugeValue, usChan.PowerDBMV, labels...)
+	}
+
+	for severity, count := range status.EventLogEntries {
+		ch <- prometheus.MustNewConstMetric(e.eventLogEntries, prometheus.CounterValue, count, e.modem.Name, severity)
+	}
 
-	// Callback to parse the tbody block of table with id=dsTable, the downstream table info.
-	c.OnHTML(`#dsTable tbody`, func(elem *colly.HTMLElement) {
-		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
-			if i == 0 {
-				return // no rows were returned
+	e.totalScrapes.Collect(ch)
+	e.scrapeErrors.Collect(ch)
+	e.scrapeDuration.Collect(ch)
+	e.channels.Collect(ch)
+}
+
+// probeHandler scrapes the modem(s) selected by the "target" query parameter
+// (or every configured modem, if target is omitted) and writes the result in
+// the Prometheus text exposition format. Modems are scraped concurrently.
+func probeHandler(config *Config, totalScrapes, scrapeErrors *prometheus.CounterVec, scrapeDuration *prometheus.HistogramVec, channels *prometheus.GaugeVec, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var modems []Modem
+		if target := r.URL.Query().Get("target"); target != "" {
+			modem, ok := config.FindModem(target)
+			if !ok {
+				http.Error(w, fmt.Sprintf("unknown target %q", target), http.StatusNotFound)
+				return
 			}
-			var (
-				channel    string
-				lockStatus string
-				modulation string
-				channelID  string
-				freqMHz    string
-				snr        float64
-				power      float64
-				corrErrs   float64
-				unCorrErrs float64
-			)
-			row.Find("td").Each(func(j int, col *goquery.Selection) {
-				text := strings.TrimSpace(col.Text())
-
-				switch j {
-				case 0:
-					channel = text
-				case 1:
-					lockStatus = text
-				case 2:
-					modulation = text
-				case 3:
-					channelID = text
-				case 4:
-					{
-						var freqHZ float64
-						fmt.Sscanf(text, "%f Hz", &freqHZ)
-						freqMHz = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
-					}
-				case 5:
-					fmt.Sscanf(text, "%f dBmV", &power)
-				case 6:
-					fmt.Sscanf(text, "%f dB", &snr)
-				case 7:
-					fmt.Sscanf(text, "%f", &corrErrs)
-				case 8:
-					fmt.Sscanf(text, "%f", &unCorrErrs)
+			modems = []Modem{modem}
+		} else {
+			modems = config.Modems
+		}
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			families = map[string]*dto.MetricFamily{}
+			backends int
+		)
+		for _, modem := range modems {
+			modem := modem
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				backend, err := NewModemBackend(modem)
+				if err != nil {
+					logger.Error("probe failed", "modem", modem.Name, "error", err)
+					scrapeErrors.WithLabelValues(modem.Name, "config").Inc()
+					return
 				}
-			})
-			labels := []string{channel, lockStatus, modulation, channelID, freqMHz}
-
-			ch <- prometheus.MustNewConstMetric(e.dsChannelSNR, prometheus.GaugeValue, snr, labels...)
-			ch <- prometheus.MustNewConstMetric(e.dsChannelPower, prometheus.GaugeValue, power, labels...)
-			ch <- prometheus.MustNewConstMetric(e.dsChannelCorrectableErrs, prometheus.CounterValue, corrErrs, labels...)
-			ch <- prometheus.MustNewConstMetric(e.dsChannelUncorrectableErrs, prometheus.CounterValue, unCorrErrs, labels...)
-		})
-	})
-
-	// Callback to parse the tbody block of table with id=usTable, the upstream channel info.
-	c.OnHTML(`#usTable tbody`, func(elem *colly.HTMLElement) {
-		elem.DOM.Find("tr").Each(func(i int, row *goquery.Selection) {
-			if i == 0 {
-				return // no rows were returned
-			}
-			var (
-				channel     string
-				lockStatus  string
-				channelType string
-				channelID   string
-				symbolRate  float64
-				freqMHz     string
-				power       float64
-			)
-			row.Find("td").Each(func(j int, col *goquery.Selection) {
-				text := strings.TrimSpace(col.Text())
-				switch j {
-				case 0:
-					channel = text
-				case 1:
-					lockStatus = text
-				case 2:
-					channelType = text
-				case 3:
-					channelID = text
-				case 4:
-					{
-						fmt.Sscanf(text, "%f Ksym/sec", &symbolRate)
-						symbolRate = symbolRate * 1000 // convert to sym/sec
-					}
-				case 5:
-					{
-						var freqHZ float64
-						fmt.Sscanf(text, "%f Hz", &freqHZ)
-						freqMHz = fmt.Sprintf("%0.2f MHz", freqHZ/1e6)
+
+				registry := prometheus.NewRegistry()
+				registry.MustRegister(NewExporter(
+					modem, backend,
+					totalScrapes.WithLabelValues(modem.Name),
+					scrapeErrors.MustCurryWith(prometheus.Labels{"modem": modem.Name}),
+					scrapeDuration.MustCurryWith(prometheus.Labels{"modem": modem.Name}),
+					channels.MustCurryWith(prometheus.Labels{"modem": modem.Name}),
+					logger,
+				))
+
+				mfs, err := registry.Gather()
+				if err != nil {
+					logger.Error("probe failed", "modem", modem.Name, "error", err)
+				}
+
+				mu.Lock()
+				backends++
+				// Merge by name instead of appending raw slices: every
+				// modem's registry emits its own MetricFamily with the same
+				// name, and the exposition format forbids repeating a
+				// metric name's HELP/TYPE block.
+				for _, mf := range mfs {
+					existing, ok := families[mf.GetName()]
+					if !ok {
+						families[mf.GetName()] = mf
+						continue
 					}
-				case 6:
-					fmt.Sscanf(text, "%f dBmV", &power)
+					existing.Metric = append(existing.Metric, mf.Metric...)
 				}
-			})
-			labels := []string{channel, lockStatus, channelType, channelID, freqMHz}
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		if len(modems) > 0 && backends == 0 {
+			http.Error(w, "failed to create a backend for every requested modem", http.StatusInternalServerError)
+			return
+		}
+
+		names := make([]string, 0, len(families))
+		for name := range families {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		w.Header().Set("Content-Type", string(expfmt.FmtText))
+		enc := expfmt.NewEncoder(w, expfmt.FmtText)
+		for _, name := range names {
+			if err := enc.Encode(families[name]); err != nil {
+				logger.Error("failed to encode metric family", "error", err)
+			}
+		}
+	}
+}
 
-			ch <- prometheus.MustNewConstMetric(e.usChannelPower, prometheus.GaugeValue, power, labels...)
-			ch <- prometheus.MustNewConstMetric(e.usChannelSymbolRate, prometheus.GaugeValue, symbolRate, labels...)
-		})
-	})
+// landingPageTemplate renders the "/" page, listing build information and
+// the configured modem targets.
+var landingPageTemplate = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Netgear Cable Modem Exporter</title></head>
+<body>
+<h1>Netgear Cable Modem Exporter</h1>
+<p>
+version={{.Version}} revision={{.Revision}} branch={{.Branch}} buildUser={{.BuildUser}} buildDate={{.BuildDate}}
+</p>
+<p><a href="{{.MetricsPath}}">Metrics</a></p>
+<h2>Modems</h2>
+<ul>
+{{range .Modems}}<li><a href="{{$.ProbePath}}?target={{.Name}}">{{.Name}}</a> ({{.Model}} at {{.Address}})</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// landingPageData is the template data for landingPageTemplate.
+type landingPageData struct {
+	Version, Revision, Branch, BuildUser, BuildDate string
+	MetricsPath, ProbePath                          string
+	Modems                                          []Modem
+}
 
-	e.mu.Lock()
-	c.Visit(e.url)
-	e.totalScrapes.Collect(ch)
-	e.scrapeErrors.Collect(ch)
-	e.mu.Unlock()
+// landingPageHandler serves a small HTML page listing build info and the
+// configured modem targets, in place of a bare redirect to MetricsPath.
+func landingPageHandler(config *Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := landingPageData{
+			Version:     version,
+			Revision:    revision,
+			Branch:      branch,
+			BuildUser:   buildUser,
+			BuildDate:   buildDate,
+			MetricsPath: config.Telemetry.MetricsPath,
+			ProbePath:   config.Telemetry.ProbePath,
+			Modems:      config.Modems,
+		}
+		if err := landingPageTemplate.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// parseLogLevel parses the --log.level flag value into a slog.Level.
+func parseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unrecognized log level %q", level)
+	}
+}
+
+// newLogger returns a *slog.Logger writing to stdout at level, in either
+// logfmt or json format.
+func newLogger(format string, level slog.Level) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(format) {
+	case "logfmt":
+		return slog.New(slog.NewTextHandler(os.Stdout, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stdout, opts)), nil
+	default:
+		return nil, fmt.Errorf("unrecognized log format %q", format)
+	}
 }
 
 func main() {
 	var (
 		configFile  = flag.String("config.file", "netgear_cm_exporter.yml", "Path to configuration file.")
 		showVersion = flag.Bool("version", false, "Print version information.")
+		logLevel    = flag.String("log.level", "info", "Only log messages with the given severity or above. One of: [debug, info, warn, error].")
+		logFormat   = flag.String("log.format", "logfmt", "Output format of log messages. One of: [logfmt, json].")
 	)
 	flag.Parse()
 
@@ -269,22 +476,52 @@ func main() {
 		os.Exit(0)
 	}
 
-	config, err := NewConfigFromFile(*configFile)
+	level, err := parseLogLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	logger, err := newLogger(*logFormat, level)
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	exporter := NewExporter(config.Modem.Address, config.Modem.Username, config.Modem.Password)
+	config, err := NewConfigFromFile(*configFile)
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
 
-	prometheus.MustRegister(exporter)
+	totalScrapes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "status_scrapes_total",
+		Help:      "Total number of scrapes of the modem status page.",
+	}, []string{"modem"})
+	scrapeErrors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "status_scrape_errors_total",
+		Help:      "Total number of failed scrapes of the modem status page, by failure reason.",
+	}, []string{"modem", "reason"})
+	scrapeDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scrape_duration_seconds",
+		Help:      "Duration of modem status page scrapes in seconds, by result.",
+	}, []string{"modem", "result"})
+	channels := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "channels",
+		Help:      "Number of channels parsed from the modem status page, by direction.",
+	}, []string{"modem", "direction"})
+	prometheus.MustRegister(totalScrapes, scrapeErrors, scrapeDuration, channels)
 
 	http.Handle(config.Telemetry.MetricsPath, promhttp.Handler())
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, config.Telemetry.MetricsPath, http.StatusMovedPermanently)
-	})
+	http.HandleFunc(config.Telemetry.ProbePath, probeHandler(config, totalScrapes, scrapeErrors, scrapeDuration, channels, logger))
+	http.HandleFunc("/", landingPageHandler(config))
 
-	log.Printf("exporter listening on %s", config.Telemetry.ListenAddress)
+	logger.Info("exporter listening", "address", config.Telemetry.ListenAddress, "modems", len(config.Modems))
 	if err := http.ListenAndServe(config.Telemetry.ListenAddress, nil); err != nil {
-		log.Fatalf("failed to start netgear exporter: %s", err)
+		logger.Error("failed to start netgear exporter", "error", err)
+		os.Exit(1)
 	}
 }